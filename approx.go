@@ -0,0 +1,41 @@
+package deep
+
+import "math"
+
+// EquateApprox returns an option that compares floats for approximate
+// equality instead of formatting them to FloatPrecision decimal places,
+// which produces false negatives near zero and false positives for very
+// large numbers. Two floats a and b are equal if |a-b| <= margin, or if
+// |a-b|/min(|a|,|b|) <= fraction (the fraction test is skipped if either
+// value is zero, to avoid dividing by zero). Infinities compare equal only
+// if they're identical (+Inf == +Inf, but +Inf != -Inf).
+func EquateApprox(fraction, margin float64) Option {
+	return func(o *options) {
+		o.approxSet = true
+		o.approxFraction = fraction
+		o.approxMargin = margin
+	}
+}
+
+// EquateNaNs returns an option that treats NaN as equal to NaN, which the
+// IEEE 754 definition of == does not.
+func EquateNaNs() Option {
+	return func(o *options) {
+		o.equateNaNs = true
+	}
+}
+
+func approxEqual(a, b, fraction, margin float64) bool {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+	diff := math.Abs(a - b)
+	if diff <= margin {
+		return true
+	}
+	minAbs := math.Min(math.Abs(a), math.Abs(b))
+	if minAbs == 0 {
+		return false
+	}
+	return diff/minAbs <= fraction
+}
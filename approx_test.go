@@ -0,0 +1,75 @@
+package deep
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEquateApproxMargin(t *testing.T) {
+	diff := Equal(1.0, 1.0000001, EquateApprox(0, 0.001))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: within margin should compare equal", diff)
+	}
+
+	diff = Equal(1.0, 1.1, EquateApprox(0, 0.001))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: outside margin should not compare equal")
+	}
+}
+
+func TestEquateApproxFraction(t *testing.T) {
+	diff := Equal(1000.0, 1001.0, EquateApprox(0.01, 0))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: within fraction should compare equal", diff)
+	}
+
+	diff = Equal(1000.0, 1100.0, EquateApprox(0.01, 0))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: outside fraction should not compare equal")
+	}
+}
+
+func TestEquateApproxZeroSkipsFraction(t *testing.T) {
+	// The fraction test divides by min(|a|, |b|), so it must be skipped
+	// when either value is zero rather than dividing by zero.
+	diff := Equal(0.0, 0.0, EquateApprox(0.01, 0))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: 0 and 0 are equal regardless of fraction", diff)
+	}
+
+	diff = Equal(0.0, 0.0001, EquateApprox(0.01, 0))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: the fraction test can't apply against zero, so a nonzero value must differ")
+	}
+}
+
+func TestEquateApproxInfinities(t *testing.T) {
+	diff := Equal(math.Inf(1), math.Inf(1), EquateApprox(0.01, 1))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: +Inf should equal +Inf", diff)
+	}
+
+	diff = Equal(math.Inf(1), math.Inf(-1), EquateApprox(0.01, 1))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: +Inf should not equal -Inf")
+	}
+
+	diff = Equal(math.Inf(1), 1e300, EquateApprox(0.01, 1))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: +Inf should not equal a large finite value")
+	}
+}
+
+func TestEquateNaNs(t *testing.T) {
+	nan := math.NaN()
+
+	diff := Equal(nan, nan, EquateNaNs())
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: EquateNaNs should treat NaN as equal to NaN", diff)
+	}
+
+	diff = Equal(nan, 1.0, EquateNaNs())
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: EquateNaNs only special-cases NaN vs NaN, not NaN vs a real number")
+	}
+}
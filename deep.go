@@ -31,8 +31,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"reflect"
-	"strings"
 )
 
 var (
@@ -50,34 +50,42 @@ var (
 )
 
 type cmp struct {
-	diff        []string
-	buff        []string
+	diffs       []Diff
+	buff        []PathStep
 	floatFormat string
+	opts        *options
 }
 
 // Equal compares variables a and b, recursing into their structure up to
-// MaxDepth levels deep, and returns a list of differences, or nil if there are
-// none. Some differences may not be found if an error is also returned.
+// MaxDepth levels deep (or the depth set by WithMaxDepth), and returns a
+// list of differences, or nil if there are none. Some differences may not
+// be found if an error is also returned.
 //
 // If a type has an Equal method, like time.Equal, it is called to check for
-// equality.
-func Equal(a, b interface{}) []string {
-	aVal := reflect.ValueOf(a)
-	bVal := reflect.ValueOf(b)
-	c := &cmp{
-		diff:        []string{},
-		buff:        []string{},
-		floatFormat: fmt.Sprintf("%%.%df", FloatPrecision),
+// equality, unless a WithEqualityFunc option was registered for that type.
+//
+// Without opts, Equal behaves exactly as before: it reads its configuration
+// from the package-level vars FloatPrecision, MaxDepth, MaxDiff, and
+// CompareUnexportedFields. Any opts passed override those globals for this
+// call only, so concurrent callers can use independent configurations.
+//
+// Equal is implemented on top of Compare, formatting each Diff the way it
+// always has. Callers who need the unflattened path or mismatch kind, e.g.
+// for a machine-readable report, should call Compare directly.
+func Equal(a, b interface{}, opts ...Option) []string {
+	diffs := Compare(a, b, opts...)
+	if len(diffs) == 0 {
+		return nil
 	}
-	c.equals(aVal, bVal, 0)
-	if len(c.diff) > 0 {
-		return c.diff // diffs
+	out := make([]string, len(diffs))
+	for i, d := range diffs {
+		out[i] = d.String()
 	}
-	return nil // no diffs
+	return out
 }
 
 func (c *cmp) equals(a, b reflect.Value, level int) {
-	if level > MaxDepth {
+	if level > c.opts.maxDepth {
 		logError(ErrMaxRecursion)
 		return
 	}
@@ -85,13 +93,24 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 	aType := a.Type()
 	bType := b.Type()
 	if aType != bType {
-		c.saveDiff(aType, bType)
+		c.saveDiff(TypeMismatch, aType, bType)
 		logError(ErrTypeMismatch)
 		return
 	}
 
 	aKind := a.Kind()
 	bKind := b.Kind()
+	indirection := aKind == reflect.Ptr || aKind == reflect.Interface
+
+	// ignoreCheckType is the type IgnoreTypes/IgnoreFieldsOfType should
+	// match against. It's computed before dereferencing so that a nil
+	// *time.Time is still recognized as time.Time: once dereferenced, a nil
+	// pointer's Value is invalid and carries no usable Type.
+	ignoreCheckType := aType
+	if aKind == reflect.Ptr {
+		ignoreCheckType = aType.Elem()
+	}
+
 	if aKind == reflect.Ptr || aKind == reflect.Interface {
 		a = a.Elem()
 		aKind = a.Kind()
@@ -107,12 +126,48 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 		}
 	}
 
+	// IgnoreTypes(aType) suppresses the diff entirely, wherever aType is
+	// encountered: top-level, a struct field, a map value, a slice element
+	// — even when one side is a nil pointer of that type.
+	if c.opts.ignoredTypes[ignoreCheckType] {
+		return
+	}
+
 	// For example: T{x: *X} and T.x is nil.
 	if !a.IsValid() || !b.IsValid() {
 		if a.IsValid() && !b.IsValid() {
-			c.saveDiff(aType, "<nil pointer>")
+			c.saveDiff(NilMismatch, aType, "<nil pointer>")
 		} else if !a.IsValid() && b.IsValid() {
-			c.saveDiff("<nil pointer>", bType)
+			c.saveDiff(NilMismatch, "<nil pointer>", bType)
+		}
+		return
+	}
+
+	// Record the indirection in the path, if WithIndirectionSteps is set, so
+	// a Diff's Path reflects that a pointer or interface was dereferenced to
+	// reach the value actually being compared, e.g. Compare on a struct with
+	// a *Address field reports "Address.*.City" instead of "Address.City".
+	// Off by default: Equal's output format, and IgnoreFields patterns
+	// (which ignore StepPointer segments regardless; see pathIgnored), stay
+	// the same whether or not a field happens to be a pointer.
+	if indirection && c.opts.trackIndirections {
+		c.pushPointer()
+		defer c.pop()
+	}
+
+	// A Transform registered for this type normalizes both values and
+	// recurses on the results instead of comparing a and b directly.
+	if c.applyTransform(aType, a, b, level) {
+		return
+	}
+
+	// A WithEqualityFunc registered for this type takes priority over a
+	// built-in Equal method and the kind-based comparison below (a Transform
+	// registered for the same type, above, still runs first).
+	if eqFunc, ok := c.opts.equalityFuncs[aType]; ok {
+		retVals := eqFunc.Call([]reflect.Value{a, b})
+		if !retVals[0].Bool() {
+			c.saveDiff(ValueMismatch, a, b)
 		}
 		return
 	}
@@ -122,7 +177,7 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 	if eqFunc.IsValid() {
 		retVals := eqFunc.Call([]reflect.Value{b})
 		if !retVals[0].Bool() {
-			c.saveDiff(a, b)
+			c.saveDiff(ValueMismatch, a, b)
 		}
 		return
 	}
@@ -145,11 +200,16 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 			Iterate through the fields (FirstName, LastName), recurse into their values.
 		*/
 		for i := 0; i < a.NumField(); i++ {
-			if aType.Field(i).PkgPath != "" && !CompareUnexportedFields {
+			field := aType.Field(i)
+			if field.PkgPath != "" && (!c.opts.compareUnexportedFields || c.opts.unexportedIgnoredIn[aType]) {
 				continue // skip unexported field, e.g. s in type T struct {s string}
 			}
 
-			c.push(aType.Field(i).Name) // push field name to buff
+			if c.opts.ignoredFieldsOfType[field.Type] || c.pathIgnored(field.Name) {
+				continue // skip field suppressed by IgnoreFieldsOfType or IgnoreFields
+			}
+
+			c.pushField(field.Name) // push field name to buff
 
 			// Get the Value for each field, e.g. FirstName has Type = string,
 			// Kind = reflect.String.
@@ -161,7 +221,7 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 
 			c.pop() // pop field name from buff
 
-			if len(c.diff) >= MaxDiff {
+			if len(c.diffs) >= c.opts.maxDiff {
 				break
 			}
 		}
@@ -183,9 +243,9 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 
 		if a.IsNil() || b.IsNil() {
 			if a.IsNil() && !b.IsNil() {
-				c.saveDiff("<nil map>", b)
+				c.saveDiff(NilMismatch, "<nil map>", b)
 			} else if !a.IsNil() && b.IsNil() {
-				c.saveDiff(a, "<nil map>")
+				c.saveDiff(NilMismatch, a, "<nil map>")
 			}
 			return
 		}
@@ -195,19 +255,23 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 		}
 
 		for _, key := range a.MapKeys() {
-			c.push(fmt.Sprintf("map[%s]", key))
+			keyPath := fmt.Sprintf("map[%v]", key)
+			if c.pathIgnored(keyPath) {
+				continue
+			}
+			c.pushMapKey(key.Interface())
 
 			aVal := a.MapIndex(key)
 			bVal := b.MapIndex(key)
 			if bVal.IsValid() {
 				c.equals(aVal, bVal, level+1)
 			} else {
-				c.saveDiff(aVal, "<does not have key>")
+				c.saveDiff(MissingKey, aVal, "<does not have key>")
 			}
 
 			c.pop()
 
-			if len(c.diff) >= MaxDiff {
+			if len(c.diffs) >= c.opts.maxDiff {
 				return
 			}
 		}
@@ -217,19 +281,23 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 				continue
 			}
 
-			c.push(fmt.Sprintf("map[%s]", key))
-			c.saveDiff("<does not have key>", b.MapIndex(key))
+			keyPath := fmt.Sprintf("map[%v]", key)
+			if c.pathIgnored(keyPath) {
+				continue
+			}
+			c.pushMapKey(key.Interface())
+			c.saveDiff(ExtraKey, "<does not have key>", b.MapIndex(key))
 			c.pop()
-			if len(c.diff) >= MaxDiff {
+			if len(c.diffs) >= c.opts.maxDiff {
 				return
 			}
 		}
 	case reflect.Slice:
 		if a.IsNil() || b.IsNil() {
 			if a.IsNil() && !b.IsNil() {
-				c.saveDiff("<nil slice>", b)
+				c.saveDiff(NilMismatch, "<nil slice>", b)
 			} else if !a.IsNil() && b.IsNil() {
-				c.saveDiff(a, "<nil slice>")
+				c.saveDiff(NilMismatch, a, "<nil slice>")
 			}
 			return
 		}
@@ -238,6 +306,20 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 			return
 		}
 
+		if c.opts.unorderedSliceTypes[aType] {
+			c.equalsSliceUnordered(a, b, true, level+1)
+			return
+		}
+		if c.opts.unorderedMultisetTypes[aType] || c.opts.compareAllSlicesUnordered {
+			c.equalsSliceUnordered(a, b, false, level+1)
+			return
+		}
+
+		if c.opts.sliceEditScript {
+			c.equalsSliceEditScript(a, b, level+1)
+			return
+		}
+
 		aLen := a.Len()
 		bLen := b.Len()
 		n := aLen
@@ -245,16 +327,20 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 			n = bLen
 		}
 		for i := 0; i < n; i++ {
-			c.push(fmt.Sprintf("slice[%d]", i))
+			idxPath := fmt.Sprintf("slice[%d]", i)
+			if c.pathIgnored(idxPath) {
+				continue
+			}
+			c.pushSliceIndex(i)
 			if i < aLen && i < bLen {
 				c.equals(a.Index(i), b.Index(i), level+1)
 			} else if i < aLen {
-				c.saveDiff(a.Index(i), "<no value>")
+				c.saveDiff(LengthMismatch, a.Index(i), "<no value>")
 			} else {
-				c.saveDiff("<no value>", b.Index(i))
+				c.saveDiff(LengthMismatch, "<no value>", b.Index(i))
 			}
 			c.pop()
-			if len(c.diff) >= MaxDiff {
+			if len(c.diffs) >= c.opts.maxDiff {
 				break
 			}
 		}
@@ -264,28 +350,39 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 	/////////////////////////////////////////////////////////////////////
 
 	case reflect.Float32, reflect.Float64:
+		af := a.Float()
+		bf := b.Float()
+		if c.opts.equateNaNs && math.IsNaN(af) && math.IsNaN(bf) {
+			return
+		}
+		if c.opts.approxSet {
+			if !approxEqual(af, bf, c.opts.approxFraction, c.opts.approxMargin) {
+				c.saveDiff(ValueMismatch, af, bf)
+			}
+			return
+		}
 		// Avoid 0.04147685731961082 != 0.041476857319611
 		// 6 decimal places is close enough
-		aval := fmt.Sprintf(c.floatFormat, a.Float())
-		bval := fmt.Sprintf(c.floatFormat, b.Float())
+		aval := fmt.Sprintf(c.floatFormat, af)
+		bval := fmt.Sprintf(c.floatFormat, bf)
 		if aval != bval {
-			c.saveDiff(a.Float(), b.Float())
+			c.saveDiff(ValueMismatch, af, bf)
 		}
 	case reflect.Bool:
 		if a.Bool() != b.Bool() {
-			c.saveDiff(a.Bool(), b.Bool())
+			c.saveDiff(ValueMismatch, a.Bool(), b.Bool())
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if a.Int() != b.Int() {
-			c.saveDiff(a.Int(), b.Int())
+			c.saveDiff(ValueMismatch, a.Int(), b.Int())
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if a.Uint() != b.Uint() {
-			c.saveDiff(a.Uint(), b.Uint())
+			c.saveDiff(ValueMismatch, a.Uint(), b.Uint())
 		}
 	case reflect.String:
 		if a.String() != b.String() {
-			c.saveDiff(a.String(), b.String())
+			c.saveDiff(ValueMismatch, a.String(), b.String())
 		}
 
 	default:
@@ -293,8 +390,20 @@ func (c *cmp) equals(a, b reflect.Value, level int) {
 	}
 }
 
-func (c *cmp) push(name string) {
-	c.buff = append(c.buff, name)
+func (c *cmp) pushField(name string) {
+	c.buff = append(c.buff, PathStep{Kind: StepField, Field: name})
+}
+
+func (c *cmp) pushMapKey(key interface{}) {
+	c.buff = append(c.buff, PathStep{Kind: StepMapKey, Key: key})
+}
+
+func (c *cmp) pushSliceIndex(i int) {
+	c.buff = append(c.buff, PathStep{Kind: StepSliceIndex, Index: i})
+}
+
+func (c *cmp) pushPointer() {
+	c.buff = append(c.buff, PathStep{Kind: StepPointer})
 }
 
 func (c *cmp) pop() {
@@ -303,13 +412,10 @@ func (c *cmp) pop() {
 	}
 }
 
-func (c *cmp) saveDiff(aval, bval interface{}) {
-	if len(c.buff) > 0 {
-		varName := strings.Join(c.buff, ".")
-		c.diff = append(c.diff, fmt.Sprintf("%s: %v != %v", varName, aval, bval))
-	} else {
-		c.diff = append(c.diff, fmt.Sprintf("%v != %v", aval, bval))
-	}
+func (c *cmp) saveDiff(kind DiffKind, aval, bval interface{}) {
+	path := make([]PathStep, len(c.buff))
+	copy(path, c.buff)
+	c.diffs = append(c.diffs, Diff{Path: path, A: aval, B: bval, Kind: kind})
 }
 
 func init() {
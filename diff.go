@@ -0,0 +1,121 @@
+package deep
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DiffKind categorizes why a Diff was recorded.
+type DiffKind int
+
+const (
+	// ValueMismatch means a and b are the same type but unequal values.
+	ValueMismatch DiffKind = iota
+	// TypeMismatch means a and b are different reflect.Types.
+	TypeMismatch
+	// MissingKey means the map key or slice element exists in a but not b.
+	MissingKey
+	// ExtraKey means the map key or slice element exists in b but not a.
+	ExtraKey
+	// LengthMismatch means a slice index exists in one of a or b but not
+	// the other.
+	LengthMismatch
+	// NilMismatch means one of a or b is a nil pointer, map, or slice and
+	// the other is not.
+	NilMismatch
+)
+
+// PathStepKind discriminates the kind of PathStep.
+type PathStepKind int
+
+const (
+	// StepField means the step is a struct field, named Field.
+	StepField PathStepKind = iota
+	// StepMapKey means the step is a map entry, keyed by Key.
+	StepMapKey
+	// StepSliceIndex means the step is a slice element at Index, or at an
+	// unspecified index (Index < 0) when order doesn't matter, e.g. for
+	// SliceAsSet/SliceAsMultiset.
+	StepSliceIndex
+	// StepPointer means the step is a pointer or interface indirection.
+	StepPointer
+)
+
+// PathStep is one segment of a Diff's Path, e.g. a struct field name, a map
+// key (with its original type preserved, not just its formatted string), or
+// a slice index.
+type PathStep struct {
+	Kind  PathStepKind
+	Field string      // set when Kind == StepField
+	Key   interface{} // set when Kind == StepMapKey; retains the key's type
+	Index int         // set when Kind == StepSliceIndex; < 0 if unordered
+}
+
+func (s PathStep) String() string {
+	switch s.Kind {
+	case StepField:
+		return s.Field
+	case StepMapKey:
+		return fmt.Sprintf("map[%v]", s.Key)
+	case StepSliceIndex:
+		if s.Index < 0 {
+			return "slice[*]"
+		}
+		return fmt.Sprintf("slice[%d]", s.Index)
+	case StepPointer:
+		return "*"
+	default:
+		return ""
+	}
+}
+
+// Diff is one difference found between two values passed to Compare. Path
+// is empty when the difference is at the top level, i.e. a and b themselves
+// differ rather than something nested inside them.
+type Diff struct {
+	Path []PathStep
+	A, B interface{}
+	Kind DiffKind
+}
+
+// String formats d the same way Equal has always formatted differences:
+// "Field.Subfield: a != b", or "a != b" at the top level.
+func (d Diff) String() string {
+	if len(d.Path) == 0 {
+		return fmt.Sprintf("%v != %v", d.A, d.B)
+	}
+	steps := make([]string, len(d.Path))
+	for i, s := range d.Path {
+		steps[i] = s.String()
+	}
+	return fmt.Sprintf("%s: %v != %v", strings.Join(steps, "."), d.A, d.B)
+}
+
+// Compare compares variables a and b, recursing into their structure up to
+// MaxDepth levels deep (or the depth set by WithMaxDepth), and returns the
+// differences found, in the same order Equal would report them, or nil if
+// there are none. Unlike Equal, which flattens every difference to a
+// string, Compare preserves the path (including, for map keys, the key's
+// original type) and the kind of mismatch, so callers can post-process
+// results programmatically, e.g. for a machine-readable CI report.
+func Compare(a, b interface{}, opts ...Option) []Diff {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	aVal := reflect.ValueOf(a)
+	bVal := reflect.ValueOf(b)
+	c := &cmp{
+		diffs:       []Diff{},
+		buff:        []PathStep{},
+		floatFormat: fmt.Sprintf("%%.%df", o.floatPrecision),
+		opts:        o,
+	}
+	c.equals(aVal, bVal, 0)
+	if len(c.diffs) > 0 {
+		return c.diffs
+	}
+	return nil
+}
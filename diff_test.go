@@ -0,0 +1,69 @@
+package deep
+
+import "testing"
+
+func TestComparePointerStep(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type T struct {
+		Addr *Address
+	}
+	a := T{Addr: &Address{City: "Boston"}}
+	b := T{Addr: &Address{City: "Seattle"}}
+
+	diffs := Compare(a, b, WithIndirectionSteps())
+	if len(diffs) != 1 {
+		t.Fatalf("got %d diffs, want 1", len(diffs))
+	}
+
+	path := diffs[0].Path
+	if len(path) != 3 || path[0].Kind != StepField || path[1].Kind != StepPointer || path[2].Kind != StepField {
+		t.Fatalf("got path %+v, want [Field(Addr), Pointer, Field(City)]", path)
+	}
+}
+
+func TestComparePointerStepOffByDefault(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type T struct {
+		Addr *Address
+	}
+	a := T{Addr: &Address{City: "Boston"}}
+	b := T{Addr: &Address{City: "Seattle"}}
+
+	diffs := Compare(a, b)
+	if len(diffs) != 1 {
+		t.Fatalf("got %d diffs, want 1", len(diffs))
+	}
+
+	path := diffs[0].Path
+	if len(path) != 2 || path[0].Kind != StepField || path[1].Kind != StepField {
+		t.Fatalf("got path %+v, want [Field(Addr), Field(City)] with no pointer step by default", path)
+	}
+	if got := diffs[0].String(); got != "Addr.City: Boston != Seattle" {
+		t.Errorf("got %q, want \"Addr.City: Boston != Seattle\": Equal's output must not change for pointer fields unless WithIndirectionSteps is set", got)
+	}
+}
+
+func TestIgnoreFieldsMatchesPointerFieldByDefault(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type T struct {
+		Addr *Address
+	}
+	a := T{Addr: &Address{City: "Boston"}}
+	b := T{Addr: &Address{City: "Seattle"}}
+
+	diff := Equal(a, b, IgnoreFields("Addr.City"))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: IgnoreFields(\"Addr.City\") should match a *Address field the same as a non-pointer one", diff)
+	}
+
+	diff = Equal(a, b, IgnoreFields("Addr.City"), WithIndirectionSteps())
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: IgnoreFields(\"Addr.City\") should still match with WithIndirectionSteps set", diff)
+	}
+}
@@ -0,0 +1,116 @@
+package deep
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// IgnoreFields returns an option that suppresses diffs at the given dotted
+// field paths, e.g. IgnoreFields("User.Password", "Meta.CreatedAt"). Paths
+// use the same notation Equal already reports in its diff strings: struct
+// fields are joined with ".", map entries look like "map[key]", and slice
+// elements look like "slice[0]". A path segment may contain a "*" wildcard
+// to match any map key or slice index, e.g. "Users.slice[*].Password".
+func IgnoreFields(paths ...string) Option {
+	return func(o *options) {
+		o.ignoredFieldPaths = append(o.ignoredFieldPaths, paths...)
+	}
+}
+
+// IgnoreTypes returns an option that suppresses diffs for any value whose
+// type matches one of the examples, wherever it's encountered in the
+// comparison: as a top-level value, a struct field, a map value, or a slice
+// element. Only the type of each example is used, e.g.
+// IgnoreTypes(time.Time{}).
+func IgnoreTypes(examples ...interface{}) Option {
+	return func(o *options) {
+		if o.ignoredTypes == nil {
+			o.ignoredTypes = map[reflect.Type]bool{}
+		}
+		for _, example := range examples {
+			o.ignoredTypes[reflect.TypeOf(example)] = true
+		}
+	}
+}
+
+// IgnoreFieldsOfType returns an option that suppresses diffs for struct
+// fields declared with one of the example types, e.g.
+// IgnoreFieldsOfType(uuid.UUID{}). Unlike IgnoreTypes, it only applies to
+// struct fields: a top-level value or slice/map element of the same type is
+// still compared.
+func IgnoreFieldsOfType(examples ...interface{}) Option {
+	return func(o *options) {
+		if o.ignoredFieldsOfType == nil {
+			o.ignoredFieldsOfType = map[reflect.Type]bool{}
+		}
+		for _, example := range examples {
+			o.ignoredFieldsOfType[reflect.TypeOf(example)] = true
+		}
+	}
+}
+
+// IgnoreUnexportedIn returns an option that skips unexported fields of the
+// given example types only, leaving CompareUnexportedFields (or
+// WithUnexported) in effect for every other type. This is the common case
+// of wanting unexported fields compared everywhere except a handful of
+// noisy types, rather than the all-or-nothing CompareUnexportedFields.
+func IgnoreUnexportedIn(examples ...interface{}) Option {
+	return func(o *options) {
+		if o.unexportedIgnoredIn == nil {
+			o.unexportedIgnoredIn = map[reflect.Type]bool{}
+		}
+		for _, example := range examples {
+			o.unexportedIgnoredIn[reflect.TypeOf(example)] = true
+		}
+	}
+}
+
+// pathIgnored reports whether next, appended to the current path (c.buff),
+// matches one of the caller's IgnoreFields patterns. StepPointer segments
+// are skipped: IgnoreFields patterns are always written in the plain dotted
+// notation, so whether WithIndirectionSteps is set shouldn't change which
+// patterns match a pointer-typed field.
+func (c *cmp) pathIgnored(next string) bool {
+	if len(c.opts.ignoredFieldPaths) == 0 {
+		return false
+	}
+	pathSegs := make([]string, 0, len(c.buff)+1)
+	for _, step := range c.buff {
+		if step.Kind == StepPointer {
+			continue
+		}
+		pathSegs = append(pathSegs, step.String())
+	}
+	pathSegs = append(pathSegs, next)
+	for _, pattern := range c.opts.ignoredFieldPaths {
+		if matchPath(pattern, pathSegs) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(pattern string, pathSegs []string) bool {
+	patternSegs := strings.Split(pattern, ".")
+	if len(patternSegs) != len(pathSegs) {
+		return false
+	}
+	for i, seg := range patternSegs {
+		ok, err := path.Match(escapeBrackets(seg), pathSegs[i])
+		if err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeBrackets escapes the "[" and "]" in a path segment pattern so that
+// path.Match treats them as the literal brackets IgnoreFields paths use
+// (e.g. "slice[*]", "map[*]") rather than as a character class, which would
+// make "*" stop meaning "any map key or slice index".
+func escapeBrackets(seg string) string {
+	return bracketEscaper.Replace(seg)
+}
+
+var bracketEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
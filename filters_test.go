@@ -0,0 +1,64 @@
+package deep
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIgnoreFieldsSliceWildcard(t *testing.T) {
+	type T struct {
+		Tags []string
+	}
+	a := T{Tags: []string{"x"}}
+	b := T{Tags: []string{"y"}}
+
+	diff := Equal(a, b, IgnoreFields("Tags.slice[*]"))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: IgnoreFields(\"Tags.slice[*]\") should suppress any slice index", diff)
+	}
+}
+
+func TestIgnoreFieldsMapWildcard(t *testing.T) {
+	type T struct {
+		Meta map[string]string
+	}
+	a := T{Meta: map[string]string{"env": "prod"}}
+	b := T{Meta: map[string]string{"env": "dev"}}
+
+	diff := Equal(a, b, IgnoreFields("Meta.map[*]"))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: IgnoreFields(\"Meta.map[*]\") should suppress any map key", diff)
+	}
+}
+
+func TestIgnoreFieldsExactSliceIndexStillComparesOthers(t *testing.T) {
+	type T struct {
+		Tags []string
+	}
+	a := T{Tags: []string{"x", "shared"}}
+	b := T{Tags: []string{"y", "shared"}}
+
+	diff := Equal(a, b, IgnoreFields("Tags.slice[0]"))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: IgnoreFields(\"Tags.slice[0]\") should suppress only index 0", diff)
+	}
+
+	diff = Equal(a, b, IgnoreFields("Tags.slice[1]"))
+	if diff == nil {
+		t.Error("got nil diff, expected a diff at Tags.slice[0] since only index 1 is ignored")
+	}
+}
+
+func TestIgnoreTypesNilPointer(t *testing.T) {
+	type T struct {
+		CreatedAt *time.Time
+	}
+	now := time.Now()
+	a := T{CreatedAt: &now}
+	b := T{CreatedAt: nil}
+
+	diff := Equal(a, b, IgnoreTypes(time.Time{}))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: IgnoreTypes(time.Time{}) should suppress a *time.Time field even when one side is nil", diff)
+	}
+}
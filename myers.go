@@ -0,0 +1,166 @@
+package deep
+
+import "reflect"
+
+// SliceEditScript returns an option that replaces the default "index i of a
+// vs index i of b" slice comparison with a Myers edit-script diff: elements
+// are matched up by content, not position, so inserting one element at the
+// front of a long slice is reported as a single insertion instead of a
+// mismatch at every following index.
+func SliceEditScript() Option {
+	return func(o *options) {
+		o.sliceEditScript = true
+	}
+}
+
+type editOp int
+
+const (
+	editEqual editOp = iota
+	editInsert
+	editDelete
+)
+
+type editStep struct {
+	op     editOp
+	aIndex int // valid for editEqual and editDelete
+	bIndex int // valid for editEqual and editInsert
+}
+
+// equalsSliceEditScript compares a and b (both already known to be non-nil
+// slices of the same type) by computing their Myers edit script and
+// reporting only the elements that were actually inserted or deleted,
+// instead of comparing every index i of a against index i of b. level is
+// the recursion depth of a's and b's elements (one deeper than a and b
+// themselves), and is passed through to valuesEqual so MaxDepth still
+// bounds comparisons of those elements.
+func (c *cmp) equalsSliceEditScript(a, b reflect.Value, level int) {
+	n, m := a.Len(), b.Len()
+	eq := func(i, j int) bool {
+		return c.valuesEqual(a.Index(i), b.Index(j), level)
+	}
+
+	for _, step := range myersEditScript(n, m, eq) {
+		switch step.op {
+		case editDelete:
+			c.pushSliceIndex(step.aIndex)
+			c.saveDiff(MissingKey, a.Index(step.aIndex), "<deleted>")
+			c.pop()
+		case editInsert:
+			c.pushSliceIndex(step.bIndex)
+			c.saveDiff(ExtraKey, "<inserted>", b.Index(step.bIndex))
+			c.pop()
+		}
+		if len(c.diffs) >= c.opts.maxDiff {
+			return
+		}
+	}
+}
+
+// myersEditScript returns the shortest edit script turning a sequence of
+// length n into one of length m, given eq(i, j) reporting whether element i
+// of the first sequence equals element j of the second. It implements
+// Myers' O(ND) algorithm: build the furthest-reaching D-path on each
+// diagonal k, snapshotting V after every D so the script can be
+// reconstructed by walking the snapshots back to front.
+func myersEditScript(n, m int, eq func(i, j int) bool) []editStep {
+	if n == 0 && m == 0 {
+		return nil
+	}
+	if n == 0 {
+		steps := make([]editStep, m)
+		for j := 0; j < m; j++ {
+			steps[j] = editStep{op: editInsert, bIndex: j}
+		}
+		return steps
+	}
+	if m == 0 {
+		steps := make([]editStep, n)
+		for i := 0; i < n; i++ {
+			steps[i] = editStep{op: editDelete, aIndex: i}
+		}
+		return steps
+	}
+
+	trace := myersTrace(n, m, eq)
+	return myersBacktrack(n, m, trace)
+}
+
+// myersTrace runs Myers' algorithm and returns, for each number of edits D
+// tried (from 0 up to the D that reaches the end of both sequences), a
+// snapshot of V: the furthest x reached on each diagonal k.
+func myersTrace(n, m int, eq func(i, j int) bool) [][]int {
+	max := n + m
+	v := make([]int, 2*max+1)
+	trace := make([][]int, 0, max+1)
+
+	for d := 0; d <= max; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+max] < v[k+1+max]) {
+				x = v[k+1+max] // came from an insertion
+			} else {
+				x = v[k-1+max] + 1 // came from a deletion
+			}
+			y := x - k
+
+			for x < n && y < m && eq(x, y) {
+				x++
+				y++
+			}
+			v[k+max] = x
+
+			if x >= n && y >= m {
+				return trace
+			}
+		}
+	}
+	return trace
+}
+
+// myersBacktrack walks trace from its last snapshot back to the first,
+// emitting Equal steps for each matched "snake" and an Insert or Delete
+// step for each move between diagonals, then reverses the result into
+// forward order.
+func myersBacktrack(n, m int, trace [][]int) []editStep {
+	max := n + m
+	x, y := n, m
+	var steps []editStep
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+max] < v[k+1+max]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+max]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			steps = append(steps, editStep{op: editEqual, aIndex: x - 1, bIndex: y - 1})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				steps = append(steps, editStep{op: editInsert, bIndex: prevY})
+			} else {
+				steps = append(steps, editStep{op: editDelete, aIndex: prevX})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
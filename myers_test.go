@@ -0,0 +1,88 @@
+package deep
+
+import "testing"
+
+func intsEqual(a, b []int) func(i, j int) bool {
+	return func(i, j int) bool { return a[i] == b[j] }
+}
+
+func TestMyersEditScriptEqual(t *testing.T) {
+	a := []int{1, 2, 3}
+	steps := myersEditScript(len(a), len(a), intsEqual(a, a))
+	for _, s := range steps {
+		if s.op != editEqual {
+			t.Fatalf("got op %v for identical slices, want only editEqual steps", s.op)
+		}
+	}
+}
+
+func TestMyersEditScriptInsertAtFront(t *testing.T) {
+	a := []int{1, 2, 3, 4, 5}
+	b := []int{0, 1, 2, 3, 4, 5}
+	steps := myersEditScript(len(a), len(b), intsEqual(a, b))
+
+	var inserts, other int
+	for _, s := range steps {
+		if s.op == editInsert {
+			inserts++
+		} else if s.op != editEqual {
+			other++
+		}
+	}
+	if inserts != 1 || other != 0 {
+		t.Errorf("got %d inserts and %d other non-equal steps, want exactly 1 insert and 0 others for a single element inserted at the front", inserts, other)
+	}
+}
+
+func TestMyersEditScriptDeleteFromMiddle(t *testing.T) {
+	a := []int{1, 2, 3, 4, 5}
+	b := []int{1, 2, 4, 5}
+	steps := myersEditScript(len(a), len(b), intsEqual(a, b))
+
+	var deletes int
+	for _, s := range steps {
+		if s.op == editDelete {
+			deletes++
+			if a[s.aIndex] != 3 {
+				t.Errorf("got delete of a[%d]=%d, want the deleted element to be 3", s.aIndex, a[s.aIndex])
+			}
+		}
+	}
+	if deletes != 1 {
+		t.Errorf("got %d deletes, want exactly 1", deletes)
+	}
+}
+
+func TestMyersEditScriptEmptySlices(t *testing.T) {
+	if steps := myersEditScript(0, 0, intsEqual(nil, nil)); steps != nil {
+		t.Errorf("got %v, want nil for two empty slices", steps)
+	}
+}
+
+func TestMyersEditScriptOneEmpty(t *testing.T) {
+	b := []int{1, 2, 3}
+	steps := myersEditScript(0, len(b), intsEqual(nil, b))
+	if len(steps) != len(b) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(b))
+	}
+	for i, s := range steps {
+		if s.op != editInsert || s.bIndex != i {
+			t.Errorf("step %d: got %+v, want an insert of bIndex %d", i, s, i)
+		}
+	}
+}
+
+func TestSliceEditScriptOption(t *testing.T) {
+	a := []int{1, 2, 3, 4, 5}
+	b := []int{0, 1, 2, 3, 4, 5}
+
+	diff := Equal(a, b, SliceEditScript())
+	if len(diff) != 1 {
+		t.Errorf("got %d diffs %v, want exactly 1: inserting one element at the front of a slice should not cascade into a mismatch at every following index", len(diff), diff)
+	}
+
+	without := Equal(a, b)
+	if len(without) != len(b) {
+		t.Errorf("got %d diffs %v without SliceEditScript, want the old positional compare to report %d mismatches", len(without), without, len(b))
+	}
+}
@@ -0,0 +1,122 @@
+package deep
+
+import "reflect"
+
+// options holds the per-call configuration for Equal. It is built from the
+// package-level defaults (FloatPrecision, MaxDepth, MaxDiff,
+// CompareUnexportedFields) and then overridden by any Option values passed
+// to Equal, so existing code that only sets the globals keeps working
+// unchanged.
+type options struct {
+	floatPrecision          int
+	maxDepth                int
+	maxDiff                 int
+	compareUnexportedFields bool
+	equalityFuncs           map[reflect.Type]reflect.Value
+
+	ignoredFieldPaths   []string
+	ignoredTypes        map[reflect.Type]bool
+	ignoredFieldsOfType map[reflect.Type]bool
+	unexportedIgnoredIn map[reflect.Type]bool
+
+	approxSet      bool
+	approxFraction float64
+	approxMargin   float64
+	equateNaNs     bool
+
+	unorderedSliceTypes       map[reflect.Type]bool // set semantics
+	unorderedMultisetTypes    map[reflect.Type]bool // multiset semantics
+	compareAllSlicesUnordered bool                  // multiset semantics for every slice
+
+	transformers map[reflect.Type]transformer
+
+	sliceEditScript   bool
+	trackIndirections bool
+}
+
+func defaultOptions() *options {
+	return &options{
+		floatPrecision:          FloatPrecision,
+		maxDepth:                MaxDepth,
+		maxDiff:                 MaxDiff,
+		compareUnexportedFields: CompareUnexportedFields,
+	}
+}
+
+// Option configures a single call to Equal. Options are applied in order, so
+// later options override earlier ones.
+type Option func(*options)
+
+// WithMaxDepth sets the maximum levels of depth to recurse, overriding
+// MaxDepth for this call.
+func WithMaxDepth(depth int) Option {
+	return func(o *options) {
+		o.maxDepth = depth
+	}
+}
+
+// WithFloatPrecision sets the number of decimal places to compare floats to,
+// overriding FloatPrecision for this call.
+func WithFloatPrecision(precision int) Option {
+	return func(o *options) {
+		o.floatPrecision = precision
+	}
+}
+
+// WithMaxDiff sets the maximum number of differences to return, overriding
+// MaxDiff for this call.
+func WithMaxDiff(max int) Option {
+	return func(o *options) {
+		o.maxDiff = max
+	}
+}
+
+// WithUnexported enables comparison of unexported struct fields, overriding
+// CompareUnexportedFields for this call.
+func WithUnexported() Option {
+	return func(o *options) {
+		o.compareUnexportedFields = true
+	}
+}
+
+// WithEqualityFunc registers fn, which must have the signature
+// func(T, T) bool for some type T, as the equality check for values of type
+// T. When equals encounters two values of type T it calls fn instead of
+// recursing, and instead of looking for an Equal method on T. This is
+// inspired by Kubernetes' semantic.Equalities: it lets callers teach Equal
+// about types that are logically equal but structurally different (e.g. a
+// type with a cache field that shouldn't affect comparison) without writing
+// an Equal method on the type itself.
+//
+// WithEqualityFunc panics if fn is not a func with two identical parameter
+// types and a single bool return value.
+func WithEqualityFunc(fn interface{}) Option {
+	fnVal := reflect.ValueOf(fn)
+	fnType := fnVal.Type()
+	if fnType.Kind() != reflect.Func ||
+		fnType.NumIn() != 2 ||
+		fnType.In(0) != fnType.In(1) ||
+		fnType.NumOut() != 1 ||
+		fnType.Out(0).Kind() != reflect.Bool {
+		panic("deep: WithEqualityFunc requires a func(T, T) bool")
+	}
+	t := fnType.In(0)
+	return func(o *options) {
+		if o.equalityFuncs == nil {
+			o.equalityFuncs = map[reflect.Type]reflect.Value{}
+		}
+		o.equalityFuncs[t] = fnVal
+	}
+}
+
+// WithIndirectionSteps returns an option that records a PathStep for every
+// pointer or interface dereferenced to reach a compared value, so a Diff's
+// Path shows the indirection, e.g. "Address.*.City" for a *Address field
+// instead of "Address.City". Off by default, so Equal's output and
+// IgnoreFields patterns (which are always written in the plain dotted
+// notation; see pathIgnored) are unaffected by pointer fields.
+func WithIndirectionSteps() Option {
+	return func(o *options) {
+		o.trackIndirections = true
+	}
+}
@@ -0,0 +1,55 @@
+package deep
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithEqualityFuncDispatch(t *testing.T) {
+	type Meters float64
+	approxEqual := func(a, b Meters) bool {
+		d := a - b
+		if d < 0 {
+			d = -d
+		}
+		return d < 1
+	}
+
+	a := Meters(10.0)
+	b := Meters(10.5)
+
+	diff := Equal(a, b, WithEqualityFunc(approxEqual))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: WithEqualityFunc should be used instead of the default float comparison", diff)
+	}
+
+	diff = Equal(a, Meters(12.0), WithEqualityFunc(approxEqual))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: 10 and 12 differ by more than the registered equality func allows")
+	}
+}
+
+func TestWithEqualityFuncTakesPriorityOverEqualMethod(t *testing.T) {
+	a := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := a.In(time.FixedZone("other", 3600))
+
+	// time.Time.Equal treats a and b as equal (same instant, different
+	// location), but a custom equality func that also checks the location
+	// should take priority over that built-in Equal method.
+	diff := Equal(a, b, WithEqualityFunc(func(x, y time.Time) bool {
+		return x.Equal(y) && x.Location() == y.Location()
+	}))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: WithEqualityFunc should override time.Time's own Equal method")
+	}
+}
+
+func TestFallsBackToEqualMethodWithoutEqualityFunc(t *testing.T) {
+	a := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := a.In(time.FixedZone("other", 3600))
+
+	diff := Equal(a, b)
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: without WithEqualityFunc, time.Time's Equal method should be used", diff)
+	}
+}
@@ -0,0 +1,137 @@
+package deep
+
+import "reflect"
+
+// SliceAsSet returns an option that compares slices of exampleSlice's type
+// without regard to element order or duplicate counts: a and b are equal if
+// they contain the same distinct elements. exampleSlice is only used for
+// its type, e.g. SliceAsSet([]string{}).
+func SliceAsSet(exampleSlice interface{}) Option {
+	return func(o *options) {
+		if o.unorderedSliceTypes == nil {
+			o.unorderedSliceTypes = map[reflect.Type]bool{}
+		}
+		o.unorderedSliceTypes[reflect.TypeOf(exampleSlice)] = true
+	}
+}
+
+// SliceAsMultiset returns an option that compares slices of exampleSlice's
+// type without regard to element order, but duplicate elements must still
+// match one-for-one: a and b are equal if every element of a can be paired
+// with an equal, not-yet-paired element of b. exampleSlice is only used for
+// its type, e.g. SliceAsMultiset([]string{}).
+func SliceAsMultiset(exampleSlice interface{}) Option {
+	return func(o *options) {
+		if o.unorderedMultisetTypes == nil {
+			o.unorderedMultisetTypes = map[reflect.Type]bool{}
+		}
+		o.unorderedMultisetTypes[reflect.TypeOf(exampleSlice)] = true
+	}
+}
+
+// CompareSliceUnordered returns an option that applies SliceAsMultiset
+// semantics to every slice encountered during the comparison, for callers
+// who don't want to enumerate each slice type they produce from maps or
+// goroutines. Slice types registered with SliceAsSet keep set semantics.
+func CompareSliceUnordered() Option {
+	return func(o *options) {
+		o.compareAllSlicesUnordered = true
+	}
+}
+
+// equalsSliceUnordered compares a and b (both already known to be non-nil
+// slices of the same type) without regard to element order. When set is
+// true, duplicate elements are collapsed before matching (set semantics);
+// otherwise every element of a must be paired with a distinct, equal
+// element of b (multiset semantics).
+func (c *cmp) equalsSliceUnordered(a, b reflect.Value, set bool, level int) {
+	var aElems, bElems []reflect.Value
+	if set {
+		aElems = c.dedupeSlice(a, level)
+		bElems = c.dedupeSlice(b, level)
+	} else {
+		for i := 0; i < a.Len(); i++ {
+			aElems = append(aElems, a.Index(i))
+		}
+		for i := 0; i < b.Len(); i++ {
+			bElems = append(bElems, b.Index(i))
+		}
+	}
+
+	used := make([]bool, len(bElems))
+	var unmatchedA []reflect.Value
+	for _, ae := range aElems {
+		matched := false
+		for j, be := range bElems {
+			if used[j] {
+				continue
+			}
+			if c.valuesEqual(ae, be, level) {
+				used[j] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatchedA = append(unmatchedA, ae)
+		}
+	}
+
+	for _, ae := range unmatchedA {
+		c.pushSliceIndex(-1)
+		c.saveDiff(MissingKey, ae, "<missing>")
+		c.pop()
+		if len(c.diffs) >= c.opts.maxDiff {
+			return
+		}
+	}
+	for j, be := range bElems {
+		if used[j] {
+			continue
+		}
+		c.pushSliceIndex(-1)
+		c.saveDiff(ExtraKey, "<extra>", be)
+		c.pop()
+		if len(c.diffs) >= c.opts.maxDiff {
+			return
+		}
+	}
+}
+
+// dedupeSlice returns the distinct elements of v, in order of first
+// appearance, using the current options to decide equality. level is the
+// recursion depth of v's elements (one deeper than v itself), and is passed
+// through to valuesEqual so MaxDepth still bounds comparisons of them.
+func (c *cmp) dedupeSlice(v reflect.Value, level int) []reflect.Value {
+	var result []reflect.Value
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i)
+		dup := false
+		for _, r := range result {
+			if c.valuesEqual(e, r, level) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// valuesEqual reports whether a and b are equal under the receiver's
+// current options, without recording any diff on the receiver itself.
+// level is the caller's current recursion depth, so that comparing two
+// elements doesn't reset MaxDepth's bound to 0 and recurse unboundedly on
+// self-referential elements.
+func (c *cmp) valuesEqual(a, b reflect.Value, level int) bool {
+	sub := &cmp{
+		diffs:       []Diff{},
+		buff:        []PathStep{},
+		floatFormat: c.floatFormat,
+		opts:        c.opts,
+	}
+	sub.equals(a, b, level)
+	return len(sub.diffs) == 0
+}
@@ -0,0 +1,61 @@
+package deep
+
+import "testing"
+
+func TestSliceAsSetIgnoresDuplicates(t *testing.T) {
+	type T struct {
+		Tags []string
+	}
+	a := T{Tags: []string{"x", "x", "y"}}
+	b := T{Tags: []string{"y", "x"}}
+
+	diff := Equal(a, b, SliceAsSet([]string{}))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: SliceAsSet should ignore duplicate counts", diff)
+	}
+}
+
+func TestSliceAsMultisetRequiresMatchingDuplicateCounts(t *testing.T) {
+	type T struct {
+		Tags []string
+	}
+	a := T{Tags: []string{"x", "x", "y"}}
+	b := T{Tags: []string{"y", "x"}}
+
+	diff := Equal(a, b, SliceAsMultiset([]string{}))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: SliceAsMultiset requires every element to pair one-for-one, so an extra \"x\" in a is unmatched")
+	}
+
+	c := T{Tags: []string{"x", "x", "y"}}
+	diff = Equal(a, c, SliceAsMultiset([]string{}))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: SliceAsMultiset should ignore order, not duplicate counts", diff)
+	}
+}
+
+func TestCompareSliceUnorderedAppliesToEverySlice(t *testing.T) {
+	a := []int{1, 2, 3}
+	b := []int{3, 2, 1}
+
+	diff := Equal(a, b, CompareSliceUnordered())
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: CompareSliceUnordered should apply multiset semantics without naming the type", diff)
+	}
+}
+
+func TestUnorderedSliceRecursionIsBoundedByMaxDepth(t *testing.T) {
+	// A cyclic structure compared under CompareSliceUnordered must still be
+	// bounded by MaxDepth, the same as an ordinary (ordered) comparison, or
+	// it will recurse forever (see valuesEqual) and crash with a stack
+	// overflow instead of returning.
+	type Node struct {
+		Children []*Node
+	}
+	a := &Node{}
+	a.Children = []*Node{a}
+	b := &Node{}
+	b.Children = []*Node{b}
+
+	Equal(a, b, CompareSliceUnordered(), WithMaxDepth(50))
+}
@@ -0,0 +1,79 @@
+package deep
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type transformer struct {
+	name string
+	fn   reflect.Value
+}
+
+// Transform returns an option that, before comparing two values of type T,
+// applies fn to both of them and recurses on the results instead. fn must
+// have the signature func(T) U for some types T and U. This is a cheaper
+// alternative to WithEqualityFunc when two values should be considered
+// equal after some normalization (e.g. reducing a *http.Request to the
+// handful of fields that matter, or converting a time.Time to UTC) rather
+// than by a wholly custom comparison.
+//
+// name is recorded in the diff path, e.g. "Field.Normalize(time.Time): ...",
+// so failures stay debuggable even though the compared values aren't the
+// original ones.
+//
+// A transformer is applied at most once per value: fn's own output is
+// compared directly rather than transformed again, which would otherwise
+// recurse forever. If fn leaves both values unchanged (they were already
+// normalized), they are compared directly with no transformer step in the
+// path. This only guards against re-applying the transform to its own output, not
+// against applying it again deeper in the tree, so a self-referential type
+// like a linked list is normalized at every level, not just the root.
+func Transform(name string, fn interface{}) Option {
+	fnVal := reflect.ValueOf(fn)
+	fnType := fnVal.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 1 || fnType.NumOut() != 1 {
+		panic("deep: Transform requires a func(T) U")
+	}
+	t := fnType.In(0)
+	return func(o *options) {
+		if o.transformers == nil {
+			o.transformers = map[reflect.Type]transformer{}
+		}
+		o.transformers[t] = transformer{name: name, fn: fnVal}
+	}
+}
+
+// applyTransform runs the transformer registered for aType on a and b, if
+// any, and recurses on the results. It reports whether a transformer was
+// applied (and thus whether the caller handled the comparison).
+func (c *cmp) applyTransform(aType reflect.Type, a, b reflect.Value, level int) bool {
+	t, ok := c.opts.transformers[aType]
+	if !ok {
+		return false
+	}
+
+	// a and b are this transformer's own output (its step is the last one
+	// on the path): compare them directly instead of transforming again.
+	step := fmt.Sprintf("%s(%s)", t.name, aType.String())
+	if n := len(c.buff); n > 0 && c.buff[n-1].Kind == StepField && c.buff[n-1].Field == step {
+		return false
+	}
+
+	aOut := t.fn.Call([]reflect.Value{a})[0]
+	bOut := t.fn.Call([]reflect.Value{b})[0]
+
+	// fn left both a and b unchanged: they're already normalized. Report
+	// "not applied" and let the caller compare a and b directly.
+	if aOut.Type() == aType && bOut.Type() == aType &&
+		reflect.DeepEqual(a.Interface(), aOut.Interface()) &&
+		reflect.DeepEqual(b.Interface(), bOut.Interface()) {
+		return false
+	}
+
+	c.pushField(step)
+	c.equals(aOut, bOut, level+1)
+	c.pop()
+
+	return true
+}
@@ -0,0 +1,59 @@
+package deep
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransformNormalizesBeforeComparing(t *testing.T) {
+	lower := func(s string) string { return strings.ToLower(s) }
+
+	diff := Equal("HELLO", "hello", Transform("lower", lower))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: Transform should normalize both values before comparing", diff)
+	}
+
+	diff = Equal("HELLO", "world", Transform("lower", lower))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch: normalized values still differ")
+	}
+}
+
+func TestTransformAppliesToEveryNestedOccurrence(t *testing.T) {
+	type LNode struct {
+		Val  string
+		Next *LNode
+	}
+	lower := func(n LNode) LNode {
+		n.Val = strings.ToLower(n.Val)
+		return n
+	}
+
+	a := LNode{Val: "Foo", Next: &LNode{Val: "BAR"}}
+	b := LNode{Val: "foo", Next: &LNode{Val: "bar"}}
+
+	diff := Equal(a, b, Transform("lower", lower))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil: Transform should normalize every nested LNode, not just the root", diff)
+	}
+}
+
+func TestTransformFixedPointDoesNotRecurseForever(t *testing.T) {
+	type T struct {
+		Val string
+	}
+	// identity is already a fixed point for every T: applying it again
+	// would produce the same value forever if applyTransform didn't detect
+	// that and fall back to a direct comparison.
+	identity := func(v T) T { return v }
+
+	diff := Equal(T{Val: "x"}, T{Val: "x"}, Transform("identity", identity))
+	if diff != nil {
+		t.Errorf("got diff %v, expected nil", diff)
+	}
+
+	diff = Equal(T{Val: "x"}, T{Val: "y"}, Transform("identity", identity))
+	if diff == nil {
+		t.Error("got nil diff, expected a mismatch")
+	}
+}